@@ -0,0 +1,308 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cache
+
+import (
+	"container/list"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// arcEntry is the value stored in the T1/T2 lists. Ghost lists (B1/B2) only
+// need to remember the key, so they store plain strings instead.
+type arcEntry struct {
+	key          string
+	value        interface{}
+	timeAccessed time.Time
+}
+
+// ARCCache is a concurrency-safe cache implementing the Adaptive Replacement
+// Cache algorithm (Megiddo & Modha, 2003). Unlike plain LRU, ARC keeps a
+// ghost history of recently evicted keys (B1, B2) and uses hits against that
+// history to adapt the target size of the "recency" list (T1) versus the
+// "frequency" list (T2), which makes it resistant to the cache pollution
+// that scan-heavy workloads cause in a pure LRU.
+type ARCCache struct {
+	mu sync.Mutex
+
+	capacity int64
+	p        int64 // target size for T1, adapted over time; 0 <= p <= capacity
+
+	t1, t2 *list.List // cached entries: T1 = recent, once-used; T2 = frequent
+	b1, b2 *list.List // ghost lists: recently evicted keys from T1 and T2
+
+	t1Index map[string]*list.Element
+	t2Index map[string]*list.Element
+	b1Index map[string]*list.Element
+	b2Index map[string]*list.Element
+
+	evictions int64
+}
+
+// NewARCCache creates a new ARCCache with the given capacity.
+func NewARCCache(capacity int64) *ARCCache {
+	return &ARCCache{
+		capacity: capacity,
+		t1:       list.New(),
+		t2:       list.New(),
+		b1:       list.New(),
+		b2:       list.New(),
+		t1Index:  make(map[string]*list.Element),
+		t2Index:  make(map[string]*list.Element),
+		b1Index:  make(map[string]*list.Element),
+		b2Index:  make(map[string]*list.Element),
+	}
+}
+
+// Get looks up key in the cache. A hit in T1 or T2 promotes the entry to the
+// front of T2. Ghost entries in B1/B2 carry no value, so a ghost "hit" is
+// reported as a miss here; the adaptation it triggers only happens once the
+// caller re-populates the key via Set.
+func (a *ARCCache) Get(key string) (interface{}, bool) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if el, ok := a.t1Index[key]; ok {
+		e := el.Value.(*arcEntry)
+		a.t1.Remove(el)
+		delete(a.t1Index, key)
+		e.timeAccessed = time.Now()
+		a.t2Index[key] = a.t2.PushFront(e)
+		return e.value, true
+	}
+	if el, ok := a.t2Index[key]; ok {
+		e := el.Value.(*arcEntry)
+		e.timeAccessed = time.Now()
+		a.t2.MoveToFront(el)
+		return e.value, true
+	}
+	return nil, false
+}
+
+// Set inserts or updates key in the cache. A key already in T1 or T2 is
+// updated and moved to the front of T2. A key found in ghost list B1 (B2)
+// grows (shrinks) the T1 target p and is re-inserted at the front of T2. Any
+// other key is inserted into T1. A new entry first evicts one if the cache is
+// full.
+func (a *ARCCache) Set(key string, value interface{}) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	now := time.Now()
+
+	if el, ok := a.t1Index[key]; ok {
+		e := el.Value.(*arcEntry)
+		e.value = value
+		e.timeAccessed = now
+		a.t1.Remove(el)
+		delete(a.t1Index, key)
+		a.t2Index[key] = a.t2.PushFront(e)
+		return
+	}
+	if el, ok := a.t2Index[key]; ok {
+		e := el.Value.(*arcEntry)
+		e.value = value
+		e.timeAccessed = now
+		a.t2.MoveToFront(el)
+		return
+	}
+	if el, ok := a.b1Index[key]; ok {
+		a.adapt(1)
+		a.b1.Remove(el)
+		delete(a.b1Index, key)
+		a.evictOne()
+		a.t2Index[key] = a.t2.PushFront(&arcEntry{key: key, value: value, timeAccessed: now})
+		return
+	}
+	if el, ok := a.b2Index[key]; ok {
+		a.adapt(-1)
+		a.b2.Remove(el)
+		delete(a.b2Index, key)
+		a.evictOne()
+		a.t2Index[key] = a.t2.PushFront(&arcEntry{key: key, value: value, timeAccessed: now})
+		return
+	}
+
+	a.evictOne()
+	a.t1Index[key] = a.t1.PushFront(&arcEntry{key: key, value: value, timeAccessed: now})
+}
+
+// adapt nudges the T1 target size p after a ghost hit. dir is +1 for a B1
+// hit (grow T1) and -1 for a B2 hit (shrink T1).
+func (a *ARCCache) adapt(dir int64) {
+	b1Len := int64(a.b1.Len())
+	b2Len := int64(a.b2.Len())
+
+	if dir > 0 {
+		delta := int64(1)
+		if b1Len > 0 && b2Len > b1Len {
+			delta = b2Len / b1Len
+		}
+		a.p += delta
+		if a.p > a.capacity {
+			a.p = a.capacity
+		}
+	} else {
+		delta := int64(1)
+		if b2Len > 0 && b1Len > b2Len {
+			delta = b1Len / b2Len
+		}
+		a.p -= delta
+		if a.p < 0 {
+			a.p = 0
+		}
+	}
+}
+
+// evictOne makes room for a single new cached entry, choosing T1 or T2 based
+// on whether T1 currently exceeds the adaptive target p, and moves the
+// evicted key onto the corresponding ghost list.
+func (a *ARCCache) evictOne() {
+	if int64(a.t1.Len()+a.t2.Len()) < a.capacity {
+		return
+	}
+	a.evictOneUnconditional()
+}
+
+// evictOneUnconditional evicts a single T1 or T2 entry regardless of the
+// current length, used to shrink the cache down to a newly lowered capacity.
+func (a *ARCCache) evictOneUnconditional() {
+	if a.t1.Len() > 0 && (int64(a.t1.Len()) > a.p || a.t2.Len() == 0) {
+		el := a.t1.Back()
+		e := el.Value.(*arcEntry)
+		a.t1.Remove(el)
+		delete(a.t1Index, e.key)
+		a.b1Index[e.key] = a.b1.PushFront(e.key)
+		a.trimGhost(a.b1, a.b1Index)
+	} else if a.t2.Len() > 0 {
+		el := a.t2.Back()
+		e := el.Value.(*arcEntry)
+		a.t2.Remove(el)
+		delete(a.t2Index, e.key)
+		a.b2Index[e.key] = a.b2.PushFront(e.key)
+		a.trimGhost(a.b2, a.b2Index)
+	} else {
+		return
+	}
+	a.evictions++
+}
+
+func (a *ARCCache) trimGhost(ghost *list.List, index map[string]*list.Element) {
+	for int64(ghost.Len()) > a.capacity {
+		el := ghost.Back()
+		ghost.Remove(el)
+		delete(index, el.Value.(string))
+	}
+}
+
+// Delete removes key from the cache or ghost lists, returning whether it was
+// present as a live (T1/T2) entry.
+func (a *ARCCache) Delete(key string) bool {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if el, ok := a.t1Index[key]; ok {
+		a.t1.Remove(el)
+		delete(a.t1Index, key)
+		return true
+	}
+	if el, ok := a.t2Index[key]; ok {
+		a.t2.Remove(el)
+		delete(a.t2Index, key)
+		return true
+	}
+	if el, ok := a.b1Index[key]; ok {
+		a.b1.Remove(el)
+		delete(a.b1Index, key)
+	}
+	if el, ok := a.b2Index[key]; ok {
+		a.b2.Remove(el)
+		delete(a.b2Index, key)
+	}
+	return false
+}
+
+// Clear empties the cache, including its ghost history and adaptive state.
+func (a *ARCCache) Clear() {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	a.t1.Init()
+	a.t2.Init()
+	a.b1.Init()
+	a.b2.Init()
+	a.t1Index = make(map[string]*list.Element)
+	a.t2Index = make(map[string]*list.Element)
+	a.b1Index = make(map[string]*list.Element)
+	a.b2Index = make(map[string]*list.Element)
+	a.p = 0
+}
+
+// Stats returns the number of live entries, the capacity and the number of
+// capacity-driven evictions the cache has performed since creation.
+func (a *ARCCache) Stats() (length, capacity, evictions int64) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return int64(a.t1.Len() + a.t2.Len()), a.capacity, a.evictions
+}
+
+// StatsJSON returns the stats as a JSON object in a string. If the cache is
+// nil, it returns an empty JSON object ("{}").
+func (a *ARCCache) StatsJSON() string {
+	if a == nil {
+		return "{}"
+	}
+	length, capacity, evictions := a.Stats()
+	a.mu.Lock()
+	p := a.p
+	a.mu.Unlock()
+	return fmt.Sprintf(`{"Length": %v, "Capacity": %v, "Evictions": %v, "P": %v}`, length, capacity, evictions, p)
+}
+
+// Length returns the number of live entries currently in the cache.
+func (a *ARCCache) Length() int64 {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return int64(a.t1.Len() + a.t2.Len())
+}
+
+// Capacity returns the capacity of the cache.
+func (a *ARCCache) Capacity() int64 {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.capacity
+}
+
+// SetCapacity changes the capacity of the cache, evicting entries and
+// capping the ghost lists and adaptive target as needed.
+func (a *ARCCache) SetCapacity(capacity int64) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	a.capacity = capacity
+	if a.p > capacity {
+		a.p = capacity
+	}
+	for int64(a.t1.Len()+a.t2.Len()) > a.capacity {
+		a.evictOneUnconditional()
+	}
+	a.trimGhost(a.b1, a.b1Index)
+	a.trimGhost(a.b2, a.b2Index)
+}
+
+var _ Cache = (*ARCCache)(nil)
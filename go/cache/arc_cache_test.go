@@ -0,0 +1,128 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cache
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestARCInitialState(t *testing.T) {
+	cache := NewARCCache(5)
+	l, c, _ := cache.Stats()
+	if l != 0 {
+		t.Errorf("length = %v, want 0", l)
+	}
+	if c != 5 {
+		t.Errorf("capacity = %v, want 5", c)
+	}
+}
+
+func TestARCSetInsertsValue(t *testing.T) {
+	cache := NewARCCache(100)
+	data := "0"
+	key := "key"
+	cache.Set(key, data)
+
+	v, ok := cache.Get(key)
+	if !ok || v != data {
+		t.Errorf("Cache has incorrect value: %v != %v", data, v)
+	}
+}
+
+func TestARCIsEvicted(t *testing.T) {
+	size := int64(3)
+	cache := NewARCCache(size)
+
+	cache.Set("key1", "1")
+	cache.Set("key2", "2")
+	cache.Set("key3", "3")
+
+	// Look up the elements, promoting them into T2.
+	cache.Get("key3")
+	cache.Get("key2")
+	cache.Get("key1")
+
+	cache.Set("key0", "0")
+
+	// With T1 empty and all entries having been promoted to T2, the LRU
+	// member of T2 (key3) should have been evicted to make room.
+	if _, ok := cache.Get("key3"); ok {
+		t.Error("Least recently used element was not evicted.")
+	}
+	if l, _, _ := cache.Stats(); l != size {
+		t.Errorf("length = %v, want %v", l, size)
+	}
+}
+
+func TestARCGhostHitPromotesAndAdaptsP(t *testing.T) {
+	cache := NewARCCache(2)
+
+	cache.Set("a", "1")
+	cache.Set("b", "2")
+	// Evicts "a" from T1 into B1.
+	cache.Set("c", "3")
+
+	if cache.p != 0 {
+		t.Fatalf("expected p == 0 before any ghost hit, got %v", cache.p)
+	}
+
+	// Re-Set "a": it's a B1 ghost hit, so p should grow and "a" should land
+	// in T2 rather than T1.
+	cache.Set("a", "1-again")
+
+	if cache.p == 0 {
+		t.Errorf("expected p to grow after a B1 hit, stayed at %v", cache.p)
+	}
+	if _, ok := cache.t2Index["a"]; !ok {
+		t.Errorf("expected %q to be promoted into T2 after a ghost hit", "a")
+	}
+}
+
+// TestARCScanResistance is the key property ARC adds over plain LRU: a long
+// one-off scan over cold keys should not be able to evict the working set of
+// hot keys that are being repeatedly re-requested, because the scanned keys
+// only ever occupy T1 while the hot keys get promoted into T2.
+func TestARCScanResistance(t *testing.T) {
+	size := int64(10)
+	cache := NewARCCache(size)
+
+	hotKeys := []string{"hot0", "hot1", "hot2"}
+	for _, k := range hotKeys {
+		cache.Set(k, k)
+	}
+	// Promote the hot keys into T2 by re-requesting them a few times.
+	for i := 0; i < 3; i++ {
+		for _, k := range hotKeys {
+			cache.Get(k)
+		}
+	}
+
+	// Now simulate a large one-time scan: each key is populated once via
+	// Set (as on a cache miss) and never requested again, far more keys
+	// than the cache capacity.
+	for i := 0; i < 1000; i++ {
+		k := fmt.Sprintf("scan%d", i)
+		cache.Set(k, k)
+	}
+
+	for _, k := range hotKeys {
+		if _, ok := cache.Get(k); !ok {
+			t.Errorf("scan-resistant cache evicted hot key %q", k)
+		}
+	}
+}
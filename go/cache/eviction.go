@@ -0,0 +1,69 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cache
+
+// EvictReason describes why an entry left the cache, so an OnEvictFunc can
+// tell routine capacity churn apart from an explicit Delete/Clear or a TTL
+// expiry.
+type EvictReason int
+
+const (
+	// ReasonCapacity means the entry was evicted to make room under the
+	// cache's capacity.
+	ReasonCapacity EvictReason = iota
+	// ReasonDelete means the entry was removed by an explicit Delete call.
+	ReasonDelete
+	// ReasonClear means the entry was removed by a Clear call.
+	ReasonClear
+	// ReasonTTL means the entry was removed because it passed its TTL
+	// deadline, either lazily on lookup or by the background janitor.
+	ReasonTTL
+	// ReasonReplace means the entry's value was overwritten by a Set call
+	// for the same key; the value handed to the callback is the old one.
+	ReasonReplace
+)
+
+func (r EvictReason) String() string {
+	switch r {
+	case ReasonCapacity:
+		return "capacity"
+	case ReasonDelete:
+		return "delete"
+	case ReasonClear:
+		return "clear"
+	case ReasonTTL:
+		return "ttl"
+	case ReasonReplace:
+		return "replace"
+	default:
+		return "unknown"
+	}
+}
+
+// OnEvictFunc is called whenever an entry leaves the cache. It is always
+// invoked outside the cache's internal lock, so it is safe for the callback
+// to call back into the cache (e.g. to Set a replacement or inspect Stats).
+type OnEvictFunc func(key string, value interface{}, reason EvictReason)
+
+// evictedEntry records an entry that left the cache during a single
+// operation, so the callback can be fired once the internal lock is
+// released.
+type evictedEntry struct {
+	key    string
+	value  interface{}
+	reason EvictReason
+}
@@ -0,0 +1,113 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cache
+
+import (
+	"testing"
+	"time"
+)
+
+type evictCall struct {
+	key    string
+	value  interface{}
+	reason EvictReason
+}
+
+func TestOnEvictFiresForCapacityEviction(t *testing.T) {
+	cache := NewLRUCache(1)
+	var calls []evictCall
+	cache.SetOnEvict(func(key string, value interface{}, reason EvictReason) {
+		calls = append(calls, evictCall{key, value, reason})
+	})
+
+	cache.Set("key1", "1")
+	cache.Set("key2", "2")
+
+	if len(calls) != 1 {
+		t.Fatalf("expected 1 eviction callback, got %d", len(calls))
+	}
+	if calls[0].key != "key1" || calls[0].value != "1" || calls[0].reason != ReasonCapacity {
+		t.Errorf("unexpected eviction callback: %+v", calls[0])
+	}
+}
+
+func TestOnEvictFiresForDeleteClearReplace(t *testing.T) {
+	cache := NewLRUCache(10)
+	var calls []evictCall
+	cache.SetOnEvict(func(key string, value interface{}, reason EvictReason) {
+		calls = append(calls, evictCall{key, value, reason})
+	})
+
+	cache.Set("key1", "1")
+	cache.Set("key1", "1-updated")
+	cache.Set("key2", "2")
+	cache.Delete("key2")
+	cache.Clear()
+
+	want := []evictCall{
+		{"key1", "1", ReasonReplace},
+		{"key2", "2", ReasonDelete},
+		{"key1", "1-updated", ReasonClear},
+	}
+	if len(calls) != len(want) {
+		t.Fatalf("got %d eviction callbacks, want %d: %+v", len(calls), len(want), calls)
+	}
+	for i, w := range want {
+		if calls[i] != w {
+			t.Errorf("call %d = %+v, want %+v", i, calls[i], w)
+		}
+	}
+}
+
+func TestOnEvictFiresForTTLExpiry(t *testing.T) {
+	cache := NewLRUCache(10)
+	var calls []evictCall
+	cache.SetOnEvict(func(key string, value interface{}, reason EvictReason) {
+		calls = append(calls, evictCall{key, value, reason})
+	})
+
+	cache.SetWithTTL("key1", "1", 10*time.Millisecond)
+	time.Sleep(20 * time.Millisecond)
+	cache.Get("key1")
+
+	if len(calls) != 1 || calls[0].reason != ReasonTTL {
+		t.Fatalf("expected a single TTL eviction callback, got %+v", calls)
+	}
+}
+
+// TestOnEvictRunsOutsideLock ensures the callback can call back into the
+// cache without deadlocking.
+func TestOnEvictRunsOutsideLock(t *testing.T) {
+	cache := NewLRUCache(1)
+	cache.SetOnEvict(func(key string, value interface{}, reason EvictReason) {
+		cache.Get(key)
+		cache.Length()
+	})
+
+	done := make(chan struct{})
+	go func() {
+		cache.Set("key1", "1")
+		cache.Set("key2", "2")
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("Set deadlocked when OnEvict called back into the cache")
+	}
+}
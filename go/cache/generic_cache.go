@@ -0,0 +1,241 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cache
+
+import (
+	"fmt"
+	"sync"
+)
+
+// genEntry is a node in a GenericCache's intrusive doubly linked list. Using
+// our own links instead of container/list avoids the per-node
+// interface{} boxing that list.Element incurs: the key and value here are
+// stored by their concrete types.
+type genEntry[K comparable, V any] struct {
+	key   K
+	value V
+	prev  *genEntry[K, V]
+	next  *genEntry[K, V]
+}
+
+// GenericCache is a concurrency-safe, generically-typed LRU cache. It is a
+// parallel API to LRUCache for callers such as the plan cache, schema cache
+// and row cache that otherwise have to type-assert every Get and pay for a
+// heap allocation boxing the value on every Set. GenericCache stores values
+// by their concrete type and, once at capacity, reuses the evicted node on
+// every subsequent Set instead of allocating a new one.
+//
+// It is named GenericCache rather than a generic LRUCache because Go does
+// not allow a generic type to share a name with the existing non-generic
+// LRUCache in this package.
+type GenericCache[K comparable, V any] struct {
+	mu sync.Mutex
+
+	table map[K]*genEntry[K, V]
+	head  *genEntry[K, V] // most recently used
+	tail  *genEntry[K, V] // least recently used
+
+	length    int64
+	capacity  int64
+	evictions int64
+}
+
+// NewGenericCache creates a new GenericCache with the given capacity.
+func NewGenericCache[K comparable, V any](capacity int64) *GenericCache[K, V] {
+	return &GenericCache[K, V]{
+		table:    make(map[K]*genEntry[K, V]),
+		capacity: capacity,
+	}
+}
+
+// Get returns a value from the cache, and marks the entry as most recently
+// used.
+func (c *GenericCache[K, V]) Get(key K) (value V, ok bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	node, ok := c.table[key]
+	if !ok {
+		var zero V
+		return zero, false
+	}
+	c.moveToFront(node)
+	return node.value, true
+}
+
+// Peek returns a value from the cache without changing its recency.
+func (c *GenericCache[K, V]) Peek(key K) (value V, ok bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	node, ok := c.table[key]
+	if !ok {
+		var zero V
+		return zero, false
+	}
+	return node.value, true
+}
+
+// Set sets a value in the cache, overwriting any existing value and marking
+// it as most recently used. Once the cache is at capacity, Set reuses the
+// evicted tail node rather than allocating a new one, so steady-state
+// inserts are allocation-free.
+func (c *GenericCache[K, V]) Set(key K, value V) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if node, ok := c.table[key]; ok {
+		node.value = value
+		c.moveToFront(node)
+		return
+	}
+
+	if c.length >= c.capacity && c.tail != nil {
+		node := c.tail
+		delete(c.table, node.key)
+		node.key = key
+		node.value = value
+		c.table[key] = node
+		c.moveToFront(node)
+		c.evictions++
+		return
+	}
+
+	node := &genEntry[K, V]{key: key, value: value}
+	c.pushFront(node)
+	c.table[key] = node
+	c.length++
+
+	// A non-positive capacity has no node to reuse above (the cache starts
+	// empty), so fall back to evicting the node we just inserted.
+	for c.length > c.capacity && c.tail != nil {
+		victim := c.tail
+		c.remove(victim)
+		delete(c.table, victim.key)
+		c.length--
+		c.evictions++
+	}
+}
+
+// Delete removes an entry from the cache, returning whether it was present.
+func (c *GenericCache[K, V]) Delete(key K) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	node, ok := c.table[key]
+	if !ok {
+		return false
+	}
+	c.remove(node)
+	delete(c.table, key)
+	c.length--
+	return true
+}
+
+// Clear empties the cache.
+func (c *GenericCache[K, V]) Clear() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.table = make(map[K]*genEntry[K, V])
+	c.head = nil
+	c.tail = nil
+	c.length = 0
+}
+
+// Stats returns the length, capacity and number of capacity-driven evictions
+// the cache has performed since creation.
+func (c *GenericCache[K, V]) Stats() (length, capacity, evictions int64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.length, c.capacity, c.evictions
+}
+
+// StatsJSON returns the stats as a JSON object in a string. If the cache is
+// nil, it returns an empty JSON object ("{}").
+func (c *GenericCache[K, V]) StatsJSON() string {
+	if c == nil {
+		return "{}"
+	}
+	length, capacity, evictions := c.Stats()
+	return fmt.Sprintf(`{"Length": %v, "Capacity": %v, "Evictions": %v}`, length, capacity, evictions)
+}
+
+// Length returns the number of entries currently in the cache.
+func (c *GenericCache[K, V]) Length() int64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.length
+}
+
+// Capacity returns the capacity of the cache.
+func (c *GenericCache[K, V]) Capacity() int64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.capacity
+}
+
+// SetCapacity changes the capacity of the cache, evicting entries if the new
+// capacity is smaller than the current length.
+func (c *GenericCache[K, V]) SetCapacity(capacity int64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.capacity = capacity
+	for c.length > c.capacity {
+		node := c.tail
+		c.remove(node)
+		delete(c.table, node.key)
+		c.length--
+		c.evictions++
+	}
+}
+
+func (c *GenericCache[K, V]) pushFront(node *genEntry[K, V]) {
+	node.prev = nil
+	node.next = c.head
+	if c.head != nil {
+		c.head.prev = node
+	}
+	c.head = node
+	if c.tail == nil {
+		c.tail = node
+	}
+}
+
+func (c *GenericCache[K, V]) remove(node *genEntry[K, V]) {
+	if node.prev != nil {
+		node.prev.next = node.next
+	} else {
+		c.head = node.next
+	}
+	if node.next != nil {
+		node.next.prev = node.prev
+	} else {
+		c.tail = node.prev
+	}
+	node.prev = nil
+	node.next = nil
+}
+
+func (c *GenericCache[K, V]) moveToFront(node *genEntry[K, V]) {
+	if c.head == node {
+		return
+	}
+	c.remove(node)
+	c.pushFront(node)
+}
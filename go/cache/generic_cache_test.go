@@ -0,0 +1,150 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cache
+
+import (
+	"strconv"
+	"testing"
+)
+
+func TestGenericCacheInitialState(t *testing.T) {
+	c := NewGenericCache[string, int](5)
+	l, capacity, _ := c.Stats()
+	if l != 0 {
+		t.Errorf("length = %v, want 0", l)
+	}
+	if capacity != 5 {
+		t.Errorf("capacity = %v, want 5", capacity)
+	}
+}
+
+func TestGenericCacheGetSetNoBoxing(t *testing.T) {
+	c := NewGenericCache[string, int](100)
+	c.Set("key", 42)
+
+	v, ok := c.Get("key")
+	if !ok || v != 42 {
+		t.Errorf("Cache has incorrect value: %v != %v", 42, v)
+	}
+}
+
+func TestGenericCacheUpdatesExisting(t *testing.T) {
+	c := NewGenericCache[string, string](100)
+	c.Set("key", "first")
+	c.Set("key", "second")
+
+	v, ok := c.Get("key")
+	if !ok || v != "second" {
+		t.Errorf("Cache has incorrect value: %v != %v", "second", v)
+	}
+	if length, _, _ := c.Stats(); length != 1 {
+		t.Errorf("length = %v, want 1", length)
+	}
+}
+
+func TestGenericCacheEvictsLRU(t *testing.T) {
+	c := NewGenericCache[string, int](2)
+	c.Set("key1", 1)
+	c.Set("key2", 2)
+	c.Get("key1") // key2 is now the LRU entry
+	c.Set("key3", 3)
+
+	if _, ok := c.Get("key2"); ok {
+		t.Error("expected key2 to have been evicted")
+	}
+	if _, ok := c.Get("key1"); !ok {
+		t.Error("expected key1 to still be present")
+	}
+	if _, ok := c.Get("key3"); !ok {
+		t.Error("expected key3 to still be present")
+	}
+}
+
+func TestGenericCacheNonPositiveCapacityNeverGrows(t *testing.T) {
+	c := NewGenericCache[string, int](0)
+	for i := 0; i < 1000; i++ {
+		c.Set(strconv.Itoa(i), i)
+	}
+	if length, _, _ := c.Stats(); length != 0 {
+		t.Errorf("length = %v, want 0 for a zero-capacity cache", length)
+	}
+
+	c = NewGenericCache[string, int](-5)
+	for i := 0; i < 1000; i++ {
+		c.Set(strconv.Itoa(i), i)
+	}
+	if length, _, _ := c.Stats(); length != 0 {
+		t.Errorf("length = %v, want 0 for a negative-capacity cache", length)
+	}
+}
+
+func TestGenericCacheDelete(t *testing.T) {
+	c := NewGenericCache[string, int](100)
+
+	if c.Delete("missing") {
+		t.Error("Item unexpectedly already in cache.")
+	}
+
+	c.Set("key", 1)
+	if !c.Delete("key") {
+		t.Error("Expected item to be in cache.")
+	}
+	if length, _, _ := c.Stats(); length != 0 {
+		t.Errorf("length = %v, want 0", length)
+	}
+}
+
+// BenchmarkGenericCacheSetAtCapacity exercises the steady-state path where
+// every Set evicts the LRU entry, which should reuse the evicted node
+// instead of allocating a new one.
+func BenchmarkGenericCacheSetAtCapacity(b *testing.B) {
+	c := NewGenericCache[string, int](1024)
+	for i := 0; i < 1024; i++ {
+		c.Set(strconv.Itoa(i), i)
+	}
+
+	keys := make([]string, b.N)
+	for i := range keys {
+		keys[i] = strconv.Itoa(i + 1024)
+	}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		c.Set(keys[i], i)
+	}
+}
+
+// BenchmarkLRUCacheSetAtCapacity is the interface{}-boxing baseline this
+// change is meant to improve on.
+func BenchmarkLRUCacheSetAtCapacity(b *testing.B) {
+	c := NewLRUCache(1024)
+	for i := 0; i < 1024; i++ {
+		c.Set(strconv.Itoa(i), i)
+	}
+
+	keys := make([]string, b.N)
+	for i := range keys {
+		keys[i] = strconv.Itoa(i + 1024)
+	}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		c.Set(keys[i], i)
+	}
+}
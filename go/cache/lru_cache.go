@@ -0,0 +1,443 @@
+/*
+Copyright 2017 Google Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package cache implements a set of in-memory cache replacement policies
+// shared by vtgate's plan cache, vttablet's plan and schema caches, and
+// other call sites that need a bounded, concurrency-safe key/value store.
+package cache
+
+import (
+	"container/list"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// Cache is the common surface implemented by every eviction policy in this
+// package, so that call sites can pick a policy via config and swap it in
+// without touching the rest of the code.
+type Cache interface {
+	Get(key string) (value interface{}, ok bool)
+	Set(key string, value interface{})
+	Delete(key string) bool
+	Clear()
+	Stats() (length, capacity, evictions int64)
+	StatsJSON() string
+	Capacity() int64
+	SetCapacity(capacity int64)
+	Length() int64
+}
+
+// Item is a key/value pair returned by Items.
+type Item struct {
+	Key   string
+	Value interface{}
+}
+
+// entry is used to hold a value in the cache's internal doubly linked list.
+// expiresAt is the zero Time when the entry carries no TTL.
+type entry struct {
+	key          string
+	value        interface{}
+	timeAccessed time.Time
+	expiresAt    time.Time
+}
+
+// LRUCache is a concurrency-safe cache that evicts the least recently used
+// entry once it reaches capacity. Capacity is measured in number of entries.
+type LRUCache struct {
+	mu sync.Mutex
+
+	list  *list.List
+	table map[string]*list.Element
+
+	capacity     int64
+	evictions    int64
+	ttlEvictions int64
+
+	// defaultTTL, when positive, is applied to every entry written through
+	// Set/SetIfAbsent. SetWithTTL always overrides it for that one entry.
+	defaultTTL time.Duration
+
+	janitorDone chan struct{}
+
+	onEvict OnEvictFunc
+}
+
+// SetOnEvict registers fn to be called whenever an entry leaves the cache.
+// fn is always invoked outside lru's internal lock.
+func (lru *LRUCache) SetOnEvict(fn OnEvictFunc) {
+	lru.mu.Lock()
+	defer lru.mu.Unlock()
+	lru.onEvict = fn
+}
+
+// fireEvictions invokes the registered OnEvict callback, if any, for each
+// recorded eviction. Callers must call this after releasing lru.mu.
+func (lru *LRUCache) fireEvictions(evicted []evictedEntry) {
+	if len(evicted) == 0 {
+		return
+	}
+	lru.mu.Lock()
+	fn := lru.onEvict
+	lru.mu.Unlock()
+	if fn == nil {
+		return
+	}
+	for _, e := range evicted {
+		fn(e.key, e.value, e.reason)
+	}
+}
+
+// NewLRUCache creates a new LRUCache with the given capacity.
+func NewLRUCache(capacity int64) *LRUCache {
+	return &LRUCache{
+		list:     list.New(),
+		table:    make(map[string]*list.Element),
+		capacity: capacity,
+	}
+}
+
+// NewLRUCacheWithTTL creates a new LRUCache with the given capacity in which
+// every entry set through Set/SetIfAbsent expires after defaultTTL unless
+// overridden per-entry via SetWithTTL.
+func NewLRUCacheWithTTL(capacity int64, defaultTTL time.Duration) *LRUCache {
+	lru := NewLRUCache(capacity)
+	lru.defaultTTL = defaultTTL
+	return lru
+}
+
+// Get returns a value from the cache, and marks the entry as most recently
+// used.
+func (lru *LRUCache) Get(key string) (v interface{}, ok bool) {
+	lru.mu.Lock()
+
+	element := lru.table[key]
+	if element == nil {
+		lru.mu.Unlock()
+		return nil, false
+	}
+	if expired, ev := lru.expireIfNeeded(element); expired {
+		lru.mu.Unlock()
+		lru.fireEvictions([]evictedEntry{ev})
+		return nil, false
+	}
+	lru.moveToFront(element)
+	v = element.Value.(*entry).value
+	lru.mu.Unlock()
+	return v, true
+}
+
+// Peek returns a value from the cache without changing its recency.
+func (lru *LRUCache) Peek(key string) (v interface{}, ok bool) {
+	lru.mu.Lock()
+
+	element := lru.table[key]
+	if element == nil {
+		lru.mu.Unlock()
+		return nil, false
+	}
+	if expired, ev := lru.expireIfNeeded(element); expired {
+		lru.mu.Unlock()
+		lru.fireEvictions([]evictedEntry{ev})
+		return nil, false
+	}
+	v = element.Value.(*entry).value
+	lru.mu.Unlock()
+	return v, true
+}
+
+// Set sets a value in the cache, overwriting any existing value and marking
+// it as most recently used. If the cache was created with
+// NewLRUCacheWithTTL, the entry expires after the configured default TTL.
+func (lru *LRUCache) Set(key string, value interface{}) {
+	lru.mu.Lock()
+	evicted := lru.set(key, value, lru.defaultTTL)
+	lru.mu.Unlock()
+	lru.fireEvictions(evicted)
+}
+
+// SetWithTTL sets a value in the cache with a per-entry TTL, overriding
+// whatever default TTL the cache was created with. A ttl <= 0 means the
+// entry never expires.
+func (lru *LRUCache) SetWithTTL(key string, value interface{}, ttl time.Duration) {
+	lru.mu.Lock()
+	evicted := lru.set(key, value, ttl)
+	lru.mu.Unlock()
+	lru.fireEvictions(evicted)
+}
+
+func (lru *LRUCache) set(key string, value interface{}, ttl time.Duration) []evictedEntry {
+	var expiresAt time.Time
+	if ttl > 0 {
+		expiresAt = time.Now().Add(ttl)
+	}
+
+	if element := lru.table[key]; element != nil {
+		old := element.Value.(*entry).value
+		evicted := lru.updateInplace(element, value, expiresAt)
+		return append([]evictedEntry{{key: key, value: old, reason: ReasonReplace}}, evicted...)
+	}
+	return lru.addNew(key, value, expiresAt)
+}
+
+// SetIfAbsent sets a value in the cache only if the key is not already
+// present.
+func (lru *LRUCache) SetIfAbsent(key string, value interface{}) {
+	lru.mu.Lock()
+	var evicted []evictedEntry
+	if element := lru.table[key]; element != nil {
+		lru.moveToFront(element)
+	} else {
+		var expiresAt time.Time
+		if lru.defaultTTL > 0 {
+			expiresAt = time.Now().Add(lru.defaultTTL)
+		}
+		evicted = lru.addNew(key, value, expiresAt)
+	}
+	lru.mu.Unlock()
+	lru.fireEvictions(evicted)
+}
+
+// Delete removes an entry from the cache, returning whether it was present.
+func (lru *LRUCache) Delete(key string) bool {
+	lru.mu.Lock()
+
+	element := lru.table[key]
+	if element == nil {
+		lru.mu.Unlock()
+		return false
+	}
+	e := element.Value.(*entry)
+	lru.list.Remove(element)
+	delete(lru.table, key)
+	lru.mu.Unlock()
+
+	lru.fireEvictions([]evictedEntry{{key: e.key, value: e.value, reason: ReasonDelete}})
+	return true
+}
+
+// Clear empties the cache.
+func (lru *LRUCache) Clear() {
+	lru.mu.Lock()
+	var evicted []evictedEntry
+	for e := lru.list.Front(); e != nil; e = e.Next() {
+		en := e.Value.(*entry)
+		evicted = append(evicted, evictedEntry{key: en.key, value: en.value, reason: ReasonClear})
+	}
+	lru.list.Init()
+	lru.table = make(map[string]*list.Element)
+	lru.mu.Unlock()
+
+	lru.fireEvictions(evicted)
+}
+
+// SetCapacity changes the capacity of the cache, evicting entries if the new
+// capacity is smaller than the current length.
+func (lru *LRUCache) SetCapacity(capacity int64) {
+	lru.mu.Lock()
+	lru.capacity = capacity
+	evicted := lru.checkCapacity()
+	lru.mu.Unlock()
+
+	lru.fireEvictions(evicted)
+}
+
+// Stats returns the length, capacity and number of capacity-driven evictions
+// the cache has performed since creation.
+func (lru *LRUCache) Stats() (length, capacity, evictions int64) {
+	lru.mu.Lock()
+	defer lru.mu.Unlock()
+	return int64(lru.list.Len()), lru.capacity, lru.evictions
+}
+
+// StatsJSON returns the stats as a JSON object in a string. If the cache is
+// nil, it returns an empty JSON object ("{}").
+func (lru *LRUCache) StatsJSON() string {
+	if lru == nil {
+		return "{}"
+	}
+	length, capacity, evictions := lru.Stats()
+	return fmt.Sprintf(`{"Length": %v, "Capacity": %v, "Evictions": %v, "TTLEvictions": %v}`, length, capacity, evictions, lru.TTLEvictions())
+}
+
+// Length returns the number of entries currently in the cache.
+func (lru *LRUCache) Length() int64 {
+	lru.mu.Lock()
+	defer lru.mu.Unlock()
+	return int64(lru.list.Len())
+}
+
+// Capacity returns the capacity of the cache.
+func (lru *LRUCache) Capacity() int64 {
+	lru.mu.Lock()
+	defer lru.mu.Unlock()
+	return lru.capacity
+}
+
+// Keys returns all the keys currently in the cache, from oldest to newest.
+func (lru *LRUCache) Keys() []string {
+	lru.mu.Lock()
+	defer lru.mu.Unlock()
+
+	keys := make([]string, 0, lru.list.Len())
+	for e := lru.list.Back(); e != nil; e = e.Prev() {
+		keys = append(keys, e.Value.(*entry).key)
+	}
+	return keys
+}
+
+// Items returns all the items currently in the cache, from oldest to newest.
+func (lru *LRUCache) Items() []Item {
+	lru.mu.Lock()
+	defer lru.mu.Unlock()
+
+	items := make([]Item, 0, lru.list.Len())
+	for e := lru.list.Back(); e != nil; e = e.Prev() {
+		v := e.Value.(*entry)
+		items = append(items, Item{Key: v.key, Value: v.value})
+	}
+	return items
+}
+
+// Oldest returns the access time of the least recently used entry, or the
+// zero time if the cache is empty.
+func (lru *LRUCache) Oldest() (oldest time.Time) {
+	lru.mu.Lock()
+	defer lru.mu.Unlock()
+
+	if lastElem := lru.list.Back(); lastElem != nil {
+		oldest = lastElem.Value.(*entry).timeAccessed
+	}
+	return
+}
+
+func (lru *LRUCache) updateInplace(element *list.Element, value interface{}, expiresAt time.Time) []evictedEntry {
+	e := element.Value.(*entry)
+	e.value = value
+	e.expiresAt = expiresAt
+	lru.moveToFront(element)
+	return lru.checkCapacity()
+}
+
+func (lru *LRUCache) moveToFront(element *list.Element) {
+	lru.list.MoveToFront(element)
+	element.Value.(*entry).timeAccessed = time.Now()
+}
+
+func (lru *LRUCache) addNew(key string, value interface{}, expiresAt time.Time) []evictedEntry {
+	newEntry := &entry{key: key, value: value, timeAccessed: time.Now(), expiresAt: expiresAt}
+	element := lru.list.PushFront(newEntry)
+	lru.table[key] = element
+	return lru.checkCapacity()
+}
+
+func (lru *LRUCache) checkCapacity() []evictedEntry {
+	var evicted []evictedEntry
+	for int64(lru.list.Len()) > lru.capacity {
+		delElem := lru.list.Back()
+		delValue := delElem.Value.(*entry)
+		lru.list.Remove(delElem)
+		delete(lru.table, delValue.key)
+		lru.evictions++
+		evicted = append(evicted, evictedEntry{key: delValue.key, value: delValue.value, reason: ReasonCapacity})
+	}
+	return evicted
+}
+
+// expireIfNeeded removes element and counts it as a TTL eviction if it has
+// passed its deadline. Callers must hold lru.mu.
+func (lru *LRUCache) expireIfNeeded(element *list.Element) (bool, evictedEntry) {
+	e := element.Value.(*entry)
+	if e.expiresAt.IsZero() || !time.Now().After(e.expiresAt) {
+		return false, evictedEntry{}
+	}
+	lru.list.Remove(element)
+	delete(lru.table, e.key)
+	lru.ttlEvictions++
+	return true, evictedEntry{key: e.key, value: e.value, reason: ReasonTTL}
+}
+
+// TTLEvictions returns the number of entries evicted for having passed their
+// TTL deadline, as opposed to being evicted to make room under capacity.
+func (lru *LRUCache) TTLEvictions() int64 {
+	lru.mu.Lock()
+	defer lru.mu.Unlock()
+	return lru.ttlEvictions
+}
+
+// StartJanitor launches a background goroutine that periodically scans the
+// cache and evicts expired entries, so long-idle entries with a TTL don't
+// pin memory until they happen to be looked up again. It is a no-op if the
+// janitor is already running; call StopJanitor first to change the
+// interval.
+func (lru *LRUCache) StartJanitor(interval time.Duration) {
+	lru.mu.Lock()
+	if lru.janitorDone != nil {
+		lru.mu.Unlock()
+		return
+	}
+	done := make(chan struct{})
+	lru.janitorDone = done
+	lru.mu.Unlock()
+
+	ticker := time.NewTicker(interval)
+	go func() {
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				lru.sweepExpired()
+			case <-done:
+				return
+			}
+		}
+	}()
+}
+
+// StopJanitor stops the background janitor goroutine started by
+// StartJanitor. It is a no-op if no janitor is running.
+func (lru *LRUCache) StopJanitor() {
+	lru.mu.Lock()
+	defer lru.mu.Unlock()
+
+	if lru.janitorDone != nil {
+		close(lru.janitorDone)
+		lru.janitorDone = nil
+	}
+}
+
+func (lru *LRUCache) sweepExpired() {
+	lru.mu.Lock()
+	now := time.Now()
+	var evicted []evictedEntry
+	for e := lru.list.Back(); e != nil; {
+		prev := e.Prev()
+		en := e.Value.(*entry)
+		if !en.expiresAt.IsZero() && now.After(en.expiresAt) {
+			lru.list.Remove(e)
+			delete(lru.table, en.key)
+			lru.ttlEvictions++
+			evicted = append(evicted, evictedEntry{key: en.key, value: en.value, reason: ReasonTTL})
+		}
+		e = prev
+	}
+	lru.mu.Unlock()
+
+	lru.fireEvictions(evicted)
+}
+
+var _ Cache = (*LRUCache)(nil)
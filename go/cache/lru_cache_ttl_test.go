@@ -0,0 +1,81 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cache
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSetWithTTLExpires(t *testing.T) {
+	cache := NewLRUCache(10)
+	cache.SetWithTTL("key", "value", 10*time.Millisecond)
+
+	if v, ok := cache.Get("key"); !ok || v != "value" {
+		t.Fatalf("expected key to be present immediately after Set, got %v %v", v, ok)
+	}
+
+	time.Sleep(20 * time.Millisecond)
+
+	if _, ok := cache.Get("key"); ok {
+		t.Error("expected key to have expired")
+	}
+	if length, _, _ := cache.Stats(); length != 0 {
+		t.Errorf("length = %v, want 0 after TTL expiry", length)
+	}
+	if e := cache.TTLEvictions(); e != 1 {
+		t.Errorf("TTLEvictions() = %v, want 1", e)
+	}
+}
+
+func TestNewLRUCacheWithTTLAppliesDefault(t *testing.T) {
+	cache := NewLRUCacheWithTTL(10, 10*time.Millisecond)
+	cache.Set("key", "value")
+
+	time.Sleep(20 * time.Millisecond)
+
+	if _, ok := cache.Get("key"); ok {
+		t.Error("expected key to have expired under the default TTL")
+	}
+}
+
+func TestSetWithTTLZeroMeansNoExpiry(t *testing.T) {
+	cache := NewLRUCacheWithTTL(10, 10*time.Millisecond)
+	cache.SetWithTTL("key", "value", 0)
+
+	time.Sleep(20 * time.Millisecond)
+
+	if _, ok := cache.Get("key"); !ok {
+		t.Error("expected an explicit zero TTL to override the cache default and never expire")
+	}
+}
+
+func TestJanitorEvictsWithoutAGet(t *testing.T) {
+	cache := NewLRUCacheWithTTL(10, 10*time.Millisecond)
+	cache.Set("key", "value")
+	cache.StartJanitor(5 * time.Millisecond)
+	defer cache.StopJanitor()
+
+	time.Sleep(50 * time.Millisecond)
+
+	if length, _, _ := cache.Stats(); length != 0 {
+		t.Errorf("length = %v, want 0; janitor should have swept the expired entry", length)
+	}
+	if e := cache.TTLEvictions(); e != 1 {
+		t.Errorf("TTLEvictions() = %v, want 1", e)
+	}
+}
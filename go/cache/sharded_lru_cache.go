@@ -0,0 +1,176 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cache
+
+import (
+	"fmt"
+	"hash/fnv"
+	"time"
+)
+
+// ShardedLRUCache fans keys out across N independent LRUCache shards by
+// FNV-1a hash of the key, each holding capacity/shards entries. LRUCache
+// serializes every Get behind a single mutex because its LRU bookkeeping
+// mutates the list on reads; sharding spreads that mutex contention across
+// shards so throughput scales with cores instead of being capped by a
+// single lock, which matters for the vtgate plan cache on many-core
+// machines.
+type ShardedLRUCache struct {
+	shards []*LRUCache
+}
+
+// NewShardedLRUCache creates a new ShardedLRUCache with the given total
+// capacity, split evenly across shards independent LRUCache instances. If
+// capacity is smaller than shards, each shard still gets a capacity of at
+// least 1 rather than rounding down to a no-op cache.
+func NewShardedLRUCache(capacity int64, shards int) *ShardedLRUCache {
+	if shards < 1 {
+		shards = 1
+	}
+	perShard := capacity / int64(shards)
+	if perShard < 1 {
+		perShard = 1
+	}
+	s := &ShardedLRUCache{
+		shards: make([]*LRUCache, shards),
+	}
+	for i := range s.shards {
+		s.shards[i] = NewLRUCache(perShard)
+	}
+	return s
+}
+
+func (s *ShardedLRUCache) shardFor(key string) *LRUCache {
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(key))
+	return s.shards[h.Sum32()%uint32(len(s.shards))]
+}
+
+// Get returns a value from the shard holding key, and marks the entry as
+// most recently used within that shard.
+func (s *ShardedLRUCache) Get(key string) (interface{}, bool) {
+	return s.shardFor(key).Get(key)
+}
+
+// Set sets a value in the shard holding key.
+func (s *ShardedLRUCache) Set(key string, value interface{}) {
+	s.shardFor(key).Set(key, value)
+}
+
+// Delete removes an entry from the shard holding key, returning whether it
+// was present.
+func (s *ShardedLRUCache) Delete(key string) bool {
+	return s.shardFor(key).Delete(key)
+}
+
+// Clear empties every shard.
+func (s *ShardedLRUCache) Clear() {
+	for _, shard := range s.shards {
+		shard.Clear()
+	}
+}
+
+// Stats returns the summed length and capacity, and the summed number of
+// capacity-driven evictions, across all shards.
+func (s *ShardedLRUCache) Stats() (length, capacity, evictions int64) {
+	for _, shard := range s.shards {
+		l, c, e := shard.Stats()
+		length += l
+		capacity += c
+		evictions += e
+	}
+	return
+}
+
+// StatsJSON returns the aggregated stats as a JSON object in a string. If
+// the cache is nil, it returns an empty JSON object ("{}").
+func (s *ShardedLRUCache) StatsJSON() string {
+	if s == nil {
+		return "{}"
+	}
+	length, capacity, evictions := s.Stats()
+	return fmt.Sprintf(`{"Length": %v, "Capacity": %v, "Evictions": %v, "Shards": %v}`, length, capacity, evictions, len(s.shards))
+}
+
+// Length returns the summed number of entries across all shards.
+func (s *ShardedLRUCache) Length() int64 {
+	var length int64
+	for _, shard := range s.shards {
+		length += shard.Length()
+	}
+	return length
+}
+
+// Capacity returns the summed capacity across all shards.
+func (s *ShardedLRUCache) Capacity() int64 {
+	var capacity int64
+	for _, shard := range s.shards {
+		capacity += shard.Capacity()
+	}
+	return capacity
+}
+
+// SetCapacity redistributes the given total capacity evenly across shards.
+// A total capacity smaller than the shard count would otherwise divide down
+// to 0 per shard, silently turning the cache into a no-op; each shard gets
+// at least 1 instead.
+func (s *ShardedLRUCache) SetCapacity(capacity int64) {
+	perShard := capacity / int64(len(s.shards))
+	if perShard < 1 {
+		perShard = 1
+	}
+	for _, shard := range s.shards {
+		shard.SetCapacity(perShard)
+	}
+}
+
+// Keys returns the keys of every shard concatenated together; ordering is
+// only meaningful within a single shard's run.
+func (s *ShardedLRUCache) Keys() []string {
+	var keys []string
+	for _, shard := range s.shards {
+		keys = append(keys, shard.Keys()...)
+	}
+	return keys
+}
+
+// Items returns the items of every shard concatenated together; ordering is
+// only meaningful within a single shard's run.
+func (s *ShardedLRUCache) Items() []Item {
+	var items []Item
+	for _, shard := range s.shards {
+		items = append(items, shard.Items()...)
+	}
+	return items
+}
+
+// Oldest returns the minimum Oldest() timestamp across all non-empty
+// shards, or the zero time if every shard is empty.
+func (s *ShardedLRUCache) Oldest() (oldest time.Time) {
+	for _, shard := range s.shards {
+		if shard.Length() == 0 {
+			continue
+		}
+		shardOldest := shard.Oldest()
+		if oldest.IsZero() || shardOldest.Before(oldest) {
+			oldest = shardOldest
+		}
+	}
+	return
+}
+
+var _ Cache = (*ShardedLRUCache)(nil)
@@ -0,0 +1,112 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cache
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestShardedLRUInitialState(t *testing.T) {
+	cache := NewShardedLRUCache(100, 4)
+	l, c, _ := cache.Stats()
+	if l != 0 {
+		t.Errorf("length = %v, want 0", l)
+	}
+	if c != 100 {
+		t.Errorf("capacity = %v, want 100", c)
+	}
+}
+
+func TestShardedLRUGetSet(t *testing.T) {
+	cache := NewShardedLRUCache(100, 8)
+	for i := 0; i < 50; i++ {
+		cache.Set(fmt.Sprintf("key%d", i), i)
+	}
+	for i := 0; i < 50; i++ {
+		v, ok := cache.Get(fmt.Sprintf("key%d", i))
+		if !ok || v != i {
+			t.Errorf("Get(key%d) = %v, %v; want %v, true", i, v, ok, i)
+		}
+	}
+	if l := cache.Length(); l != 50 {
+		t.Errorf("Length() = %v, want 50", l)
+	}
+}
+
+func TestShardedLRUDeleteAndClear(t *testing.T) {
+	cache := NewShardedLRUCache(100, 4)
+	cache.Set("key1", "1")
+	cache.Set("key2", "2")
+
+	if !cache.Delete("key1") {
+		t.Error("expected key1 to be deleted")
+	}
+	if _, ok := cache.Get("key1"); ok {
+		t.Error("expected key1 to be gone after Delete")
+	}
+
+	cache.Clear()
+	if l := cache.Length(); l != 0 {
+		t.Errorf("Length() = %v, want 0 after Clear", l)
+	}
+}
+
+func TestShardedLRUCapacitySplitAcrossShards(t *testing.T) {
+	cache := NewShardedLRUCache(40, 4)
+	for _, shard := range cache.shards {
+		if c := shard.Capacity(); c != 10 {
+			t.Errorf("shard capacity = %v, want 10", c)
+		}
+	}
+}
+
+func TestShardedLRUCapacitySmallerThanShardsDoesNotNoOp(t *testing.T) {
+	cache := NewShardedLRUCache(5, 16)
+	for _, shard := range cache.shards {
+		if c := shard.Capacity(); c < 1 {
+			t.Errorf("shard capacity = %v, want >= 1", c)
+		}
+	}
+
+	for i := 0; i < 100; i++ {
+		cache.Set(fmt.Sprintf("key%d", i), i)
+	}
+	if l := cache.Length(); l == 0 {
+		t.Error("expected cache to retain entries, got length 0")
+	}
+
+	cache.SetCapacity(2)
+	for _, shard := range cache.shards {
+		if c := shard.Capacity(); c < 1 {
+			t.Errorf("shard capacity after SetCapacity = %v, want >= 1", c)
+		}
+	}
+}
+
+func TestShardedLRUKeysAndItemsCoverAllShards(t *testing.T) {
+	cache := NewShardedLRUCache(100, 4)
+	for i := 0; i < 20; i++ {
+		cache.Set(fmt.Sprintf("key%d", i), i)
+	}
+	if keys := cache.Keys(); len(keys) != 20 {
+		t.Errorf("len(Keys()) = %v, want 20", len(keys))
+	}
+	if items := cache.Items(); len(items) != 20 {
+		t.Errorf("len(Items()) = %v, want 20", len(items))
+	}
+}
@@ -0,0 +1,287 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cache
+
+import (
+	"container/list"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// sieveEntry is the value stored in a SIEVECache's linked list node. visited
+// is an atomic.Bool rather than a plain bool because Get only takes a read
+// lock (see SIEVECache's doc comment): concurrent Gets of the same key would
+// otherwise race on a plain field write.
+type sieveEntry struct {
+	key          string
+	value        interface{}
+	visited      atomic.Bool
+	timeAccessed time.Time
+}
+
+// SIEVECache is a concurrency-safe cache implementing the SIEVE eviction
+// algorithm (Zhang, Yang, Liu et al., 2024). SIEVE keeps entries in a single
+// doubly linked list and a single "visited" bit per entry, instead of moving
+// entries around on every Get like LRU does. A persistent hand pointer walks
+// the list from tail to head to find something to evict: nodes that were
+// visited since the last pass are given a second chance and un-marked, nodes
+// that weren't are evicted. Because Get never reorders the list, lookups can
+// be served under a read lock, which is the main throughput win over LRU
+// under concurrent, read-heavy workloads such as vttablet's plan cache.
+type SIEVECache struct {
+	mu sync.RWMutex
+
+	list  *list.List
+	table map[string]*list.Element
+
+	hand *list.Element // persists across evictions; nil means "start from the tail"
+
+	capacity  int64
+	evictions int64
+}
+
+// NewSIEVECache creates a new SIEVECache with the given capacity.
+func NewSIEVECache(capacity int64) *SIEVECache {
+	return &SIEVECache{
+		list:     list.New(),
+		table:    make(map[string]*list.Element),
+		capacity: capacity,
+	}
+}
+
+// Get returns a value from the cache and sets its visited bit. Unlike LRU,
+// this does not reorder the list, so the cache only needs a read lock here.
+func (s *SIEVECache) Get(key string) (interface{}, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	element, ok := s.table[key]
+	if !ok {
+		return nil, false
+	}
+	element.Value.(*sieveEntry).visited.Store(true)
+	return element.Value.(*sieveEntry).value, true
+}
+
+// Peek returns a value from the cache without setting its visited bit.
+func (s *SIEVECache) Peek(key string) (interface{}, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	element, ok := s.table[key]
+	if !ok {
+		return nil, false
+	}
+	return element.Value.(*sieveEntry).value, true
+}
+
+// Set inserts or updates a value in the cache. A new key is always inserted
+// at the head of the list with its visited bit cleared.
+func (s *SIEVECache) Set(key string, value interface{}) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if element, ok := s.table[key]; ok {
+		e := element.Value.(*sieveEntry)
+		e.value = value
+		e.timeAccessed = time.Now()
+		return
+	}
+	s.evict()
+	e := &sieveEntry{key: key, value: value, timeAccessed: time.Now()}
+	s.table[key] = s.list.PushFront(e)
+}
+
+// SetIfAbsent sets a value in the cache only if the key is not already
+// present.
+func (s *SIEVECache) SetIfAbsent(key string, value interface{}) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if element, ok := s.table[key]; ok {
+		element.Value.(*sieveEntry).visited.Store(true)
+		return
+	}
+	s.evict()
+	e := &sieveEntry{key: key, value: value, timeAccessed: time.Now()}
+	s.table[key] = s.list.PushFront(e)
+}
+
+// evict makes room for a single new entry by walking the hand from its last
+// position towards the head of the list, giving visited nodes a second
+// chance and evicting the first unvisited one it finds. The hand persists
+// across calls rather than resetting to the tail each time.
+func (s *SIEVECache) evict() {
+	if int64(s.list.Len()) < s.capacity {
+		return
+	}
+	if s.list.Len() == 0 {
+		return
+	}
+
+	node := s.hand
+	if node == nil {
+		node = s.list.Back()
+	}
+
+	for {
+		e := node.Value.(*sieveEntry)
+		if e.visited.Load() {
+			e.visited.Store(false)
+			next := node.Prev()
+			if next == nil {
+				next = s.list.Back()
+			}
+			node = next
+			continue
+		}
+		break
+	}
+
+	victim := node
+	s.hand = victim.Prev()
+	if s.hand == nil {
+		s.hand = s.list.Back()
+		if s.hand == victim {
+			s.hand = nil
+		}
+	}
+
+	e := victim.Value.(*sieveEntry)
+	s.list.Remove(victim)
+	delete(s.table, e.key)
+	s.evictions++
+}
+
+// Delete removes an entry from the cache, returning whether it was present.
+func (s *SIEVECache) Delete(key string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	element, ok := s.table[key]
+	if !ok {
+		return false
+	}
+	if s.hand == element {
+		s.hand = element.Prev()
+	}
+	s.list.Remove(element)
+	delete(s.table, key)
+	return true
+}
+
+// Clear empties the cache.
+func (s *SIEVECache) Clear() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.list.Init()
+	s.table = make(map[string]*list.Element)
+	s.hand = nil
+}
+
+// Keys returns all the keys currently in the cache, from oldest to newest.
+func (s *SIEVECache) Keys() []string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	keys := make([]string, 0, s.list.Len())
+	for e := s.list.Back(); e != nil; e = e.Prev() {
+		keys = append(keys, e.Value.(*sieveEntry).key)
+	}
+	return keys
+}
+
+// Items returns all the items currently in the cache, from oldest to newest.
+func (s *SIEVECache) Items() []Item {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	items := make([]Item, 0, s.list.Len())
+	for e := s.list.Back(); e != nil; e = e.Prev() {
+		v := e.Value.(*sieveEntry)
+		items = append(items, Item{Key: v.key, Value: v.value})
+	}
+	return items
+}
+
+// Oldest returns the time the node the hand currently sits on — where the
+// next eviction sweep starts — was inserted or last updated by Set, or the
+// zero time if the cache is empty. Get does not change this time. Note that
+// this may not be the entry actually evicted next: a visited node at the
+// hand gets a second chance and is skipped over.
+func (s *SIEVECache) Oldest() (oldest time.Time) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	node := s.hand
+	if node == nil {
+		node = s.list.Back()
+	}
+	if node != nil {
+		oldest = node.Value.(*sieveEntry).timeAccessed
+	}
+	return
+}
+
+// Stats returns the length, capacity and number of capacity-driven evictions
+// the cache has performed since creation.
+func (s *SIEVECache) Stats() (length, capacity, evictions int64) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return int64(s.list.Len()), s.capacity, s.evictions
+}
+
+// StatsJSON returns the stats as a JSON object in a string. If the cache is
+// nil, it returns an empty JSON object ("{}").
+func (s *SIEVECache) StatsJSON() string {
+	if s == nil {
+		return "{}"
+	}
+	length, capacity, evictions := s.Stats()
+	return fmt.Sprintf(`{"Length": %v, "Capacity": %v, "Evictions": %v}`, length, capacity, evictions)
+}
+
+// Length returns the number of entries currently in the cache.
+func (s *SIEVECache) Length() int64 {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return int64(s.list.Len())
+}
+
+// Capacity returns the capacity of the cache.
+func (s *SIEVECache) Capacity() int64 {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.capacity
+}
+
+// SetCapacity changes the capacity of the cache, evicting entries if the new
+// capacity is smaller than the current length.
+func (s *SIEVECache) SetCapacity(capacity int64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.capacity = capacity
+	for int64(s.list.Len()) > s.capacity {
+		s.evict()
+	}
+}
+
+var _ Cache = (*SIEVECache)(nil)
@@ -0,0 +1,193 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cache
+
+import (
+	"encoding/json"
+	"fmt"
+	"sync"
+	"testing"
+)
+
+func TestSIEVEInitialState(t *testing.T) {
+	cache := NewSIEVECache(5)
+	l, c, _ := cache.Stats()
+	if l != 0 {
+		t.Errorf("length = %v, want 0", l)
+	}
+	if c != 5 {
+		t.Errorf("capacity = %v, want 5", c)
+	}
+}
+
+func TestSIEVESetInsertsValue(t *testing.T) {
+	cache := NewSIEVECache(100)
+	data := "0"
+	key := "key"
+	cache.Set(key, data)
+
+	v, ok := cache.Get(key)
+	if !ok || v != data {
+		t.Errorf("Cache has incorrect value: %v != %v", data, v)
+	}
+
+	k := cache.Keys()
+	if len(k) != 1 || k[0] != key {
+		t.Errorf("Cache.Keys() returned incorrect values: %v", k)
+	}
+}
+
+func TestSIEVESetIfAbsent(t *testing.T) {
+	cache := NewSIEVECache(100)
+	data := "0"
+	key := "key"
+	cache.SetIfAbsent(key, data)
+
+	v, ok := cache.Get(key)
+	if !ok || v != data {
+		t.Errorf("Cache has incorrect value: %v != %v", data, v)
+	}
+
+	cache.SetIfAbsent(key, "1")
+
+	v, ok = cache.Get(key)
+	if !ok || v != data {
+		t.Errorf("Cache has incorrect value: %v != %v", data, v)
+	}
+}
+
+func TestSIEVEDelete(t *testing.T) {
+	cache := NewSIEVECache(100)
+	value := "1"
+	key := "key"
+
+	if cache.Delete(key) {
+		t.Error("Item unexpectedly already in cache.")
+	}
+
+	cache.Set(key, value)
+
+	if !cache.Delete(key) {
+		t.Error("Expected item to be in cache.")
+	}
+
+	if length, _, _ := cache.Stats(); length != 0 {
+		t.Errorf("cache.Length() = %v, expected 0", length)
+	}
+}
+
+func TestSIEVECapacityIsObeyed(t *testing.T) {
+	size := int64(3)
+	cache := NewSIEVECache(size)
+	value := "1"
+
+	cache.Set("key1", value)
+	cache.Set("key2", value)
+	cache.Set("key3", value)
+	if length, _, _ := cache.Stats(); length != size {
+		t.Errorf("cache.Length() = %v, expected %v", length, size)
+	}
+	cache.Set("key4", value)
+	if length, _, _ := cache.Stats(); length != size {
+		t.Errorf("cache.Length() = %v, expected %v", length, size)
+	}
+
+	data := cache.StatsJSON()
+	m := make(map[string]interface{})
+	if err := json.Unmarshal([]byte(data), &m); err != nil {
+		t.Errorf("cache.StatsJSON() returned bad json data: %v %v", data, err)
+	}
+
+	cache = nil
+	if s := cache.StatsJSON(); s != "{}" {
+		t.Errorf("cache.StatsJSON() on nil object returned %v", s)
+	}
+}
+
+// TestSIEVEVisitedGetsSecondChance is the key property of SIEVE: a node
+// whose visited bit is set survives one sweep of the hand instead of being
+// evicted immediately, even though Get never moves it in the list.
+func TestSIEVEVisitedGetsSecondChance(t *testing.T) {
+	size := int64(3)
+	cache := NewSIEVECache(size)
+
+	cache.Set("key1", "1")
+	cache.Set("key2", "2")
+	cache.Set("key3", "3")
+
+	// Mark key1 (the oldest, at the tail) as visited without reordering it.
+	cache.Get("key1")
+
+	// Insert a new key; the hand starts at the tail (key1), sees it's
+	// visited, gives it a second chance, and evicts the next unvisited node
+	// (key2) instead.
+	cache.Set("key4", "4")
+
+	if _, ok := cache.Get("key1"); !ok {
+		t.Error("visited entry was evicted despite its second chance")
+	}
+	if _, ok := cache.Get("key2"); ok {
+		t.Error("expected key2 to be evicted instead of the visited key1")
+	}
+}
+
+func TestSIEVEHandPersistsAcrossEvictions(t *testing.T) {
+	size := int64(3)
+	cache := NewSIEVECache(size)
+
+	cache.Set("key1", "1")
+	cache.Set("key2", "2")
+	cache.Set("key3", "3")
+	cache.Get("key1")
+	// The hand starts at the tail (key1), clears its visited bit and evicts
+	// key2; it then rests on key3, the next node towards the head.
+	cache.Set("key4", "4")
+	if _, ok := cache.Peek("key2"); ok {
+		t.Error("expected key2 to have been evicted")
+	}
+	// The next eviction starts where the hand stopped (key3) rather than at
+	// the tail (key1, now unvisited), so key3 goes and key1 stays.
+	cache.Set("key5", "5")
+	if _, ok := cache.Peek("key3"); ok {
+		t.Error("expected key3 to have been evicted next, hand did not persist")
+	}
+	if _, ok := cache.Peek("key1"); !ok {
+		t.Error("expected key1 to survive, hand was reset to the tail")
+	}
+}
+
+// TestSIEVEConcurrentGetDoesNotRace exercises the premise behind Get's
+// read-lock-only path: many goroutines setting the visited bit on the same
+// shared entries concurrently must not race (run with -race).
+func TestSIEVEConcurrentGetDoesNotRace(t *testing.T) {
+	cache := NewSIEVECache(16)
+	for i := 0; i < 16; i++ {
+		cache.Set(fmt.Sprintf("key%d", i), i)
+	}
+
+	var wg sync.WaitGroup
+	for g := 0; g < 32; g++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 1000; i++ {
+				cache.Get(fmt.Sprintf("key%d", i%16))
+			}
+		}()
+	}
+	wg.Wait()
+}